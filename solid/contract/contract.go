@@ -0,0 +1,172 @@
+// Package contract is a small behavioral contract testing harness aimed
+// directly at the Liskov Substitution Principle: declare an interface's
+// invariants once, then check that every implementation - and in particular
+// every subtype - actually obeys them.
+package contract
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Reporter is the minimal surface Check, RandomCheck and Substitutable need
+// from a failure sink. *testing.T satisfies it directly; Recorder below is a
+// lightweight stand-in for callers that need to assert a violation was
+// detected without tripping go test's own pass/fail bookkeeping.
+type Reporter interface {
+	Helper()
+	Logf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// Recorder is a Reporter that collects failures in memory instead of
+// failing a test. It's useful for demonstrating that a subtype violates a
+// contract - the point of Substitutable - without making that demonstration
+// itself look like a failing test.
+type Recorder struct {
+	Failures []string
+}
+
+func (r *Recorder) Helper() {}
+
+func (r *Recorder) Logf(format string, args ...any) {}
+
+func (r *Recorder) Errorf(format string, args ...any) {
+	r.Failures = append(r.Failures, fmt.Sprintf(format, args...))
+}
+
+// Failed reports whether Errorf has been called at least once.
+func (r *Recorder) Failed() bool {
+	return len(r.Failures) > 0
+}
+
+// invariant is a named property that must hold for any instance produced by
+// a factory. It is free to mutate the instance it is given; most invariants
+// phrase a precondition and a postcondition in a single closure, the way
+// "width preserved after SetHeight" does.
+type invariant[T any] struct {
+	name  string
+	check func(T) bool
+}
+
+// historyInvariant checks a property after a fixed sequence of operations
+// has been applied to a fresh instance, for contracts that only make sense
+// across a history of calls rather than a single one.
+type historyInvariant[T any] struct {
+	name  string
+	ops   []func(T)
+	check func(T) bool
+}
+
+// Contract collects the invariants an interface is expected to uphold.
+type Contract[T any] struct {
+	invariants []invariant[T]
+	histories  []historyInvariant[T]
+}
+
+// For starts a new contract for interface/type T.
+func For[T any]() *Contract[T] {
+	return &Contract[T]{}
+}
+
+// Invariant registers a property that must hold for every fresh instance a
+// factory produces. check may mutate the instance before asserting on it.
+func (c *Contract[T]) Invariant(name string, check func(T) bool) *Contract[T] {
+	c.invariants = append(c.invariants, invariant[T]{name: name, check: check})
+	return c
+}
+
+// PrePost is sugar over Invariant for the common case of "run this action,
+// then assert this postcondition".
+func (c *Contract[T]) PrePost(name string, action func(T), post func(T) bool) *Contract[T] {
+	return c.Invariant(name, func(item T) bool {
+		action(item)
+		return post(item)
+	})
+}
+
+// HistoryInvariant registers a property that must hold only after a fixed
+// sequence of operations has been replayed against a fresh instance.
+func (c *Contract[T]) HistoryInvariant(name string, ops []func(T), check func(T) bool) *Contract[T] {
+	c.histories = append(c.histories, historyInvariant[T]{name: name, ops: ops, check: check})
+	return c
+}
+
+// Check runs every registered invariant against a fresh instance from each
+// factory, failing t with the factory index and invariant name on the first
+// violation per factory/invariant pair.
+func (c *Contract[T]) Check(t Reporter, factories ...func() T) {
+	t.Helper()
+
+	for i, factory := range factories {
+		for _, inv := range c.invariants {
+			if !inv.check(factory()) {
+				t.Errorf("factory %d: invariant %q violated", i, inv.name)
+			}
+		}
+
+		for _, h := range c.histories {
+			instance := factory()
+			for _, op := range h.ops {
+				op(instance)
+			}
+			if !h.check(instance) {
+				t.Errorf("factory %d: history invariant %q violated", i, h.name)
+			}
+		}
+	}
+}
+
+// RandomCheck is the property-based variant of Check: it draws trials fresh
+// instances from factory, seeded from seed, and runs every invariant and
+// history invariant against each. Each check gets its own copy of the
+// trial's instance, rebuilt from the same per-trial seed. The seed is always
+// logged so a failure can be reproduced exactly.
+func (c *Contract[T]) RandomCheck(t Reporter, seed int64, trials int, factory func(*rand.Rand) T) {
+	t.Helper()
+	t.Logf("contract: random check seed=%d trials=%d", seed, trials)
+
+	rng := rand.New(rand.NewSource(seed))
+	for trial := 0; trial < trials; trial++ {
+		trialSeed := rng.Int63()
+		fresh := func() T { return factory(rand.New(rand.NewSource(trialSeed))) }
+		for _, inv := range c.invariants {
+			if !inv.check(fresh()) {
+				t.Errorf("trial %d (seed %d): invariant %q violated", trial, seed, inv.name)
+			}
+		}
+		for _, h := range c.histories {
+			instance := fresh()
+			for _, op := range h.ops {
+				op(instance)
+			}
+			if !h.check(instance) {
+				t.Errorf("trial %d (seed %d): history invariant %q violated", trial, seed, h.name)
+			}
+		}
+	}
+}
+
+// Substitutable runs base's invariants against instances produced by
+// subFactory instead of the base factory they were written against. A
+// subtype that fails here breaks the Liskov Substitution Principle: it
+// cannot stand in for the base type without altering correctness.
+func Substitutable[T any](t Reporter, base *Contract[T], subFactory func() T) {
+	t.Helper()
+
+	for _, inv := range base.invariants {
+		if !inv.check(subFactory()) {
+			t.Errorf("substitutability violated: %q does not hold for the substituted type", inv.name)
+		}
+	}
+
+	for _, h := range base.histories {
+		instance := subFactory()
+		for _, op := range h.ops {
+			op(instance)
+		}
+		if !h.check(instance) {
+			t.Errorf("substitutability violated: history invariant %q does not hold for the substituted type", h.name)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package contract
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// incrementer is a tiny interface used to exercise PrePost, HistoryInvariant,
+// RandomCheck and Substitutable against both a compliant implementation and
+// a "subtype" that quietly breaks the contract.
+type incrementer interface {
+	Add(n int)
+	Value() int
+}
+
+type counter struct {
+	value int
+}
+
+func (c *counter) Add(n int)   { c.value += n }
+func (c *counter) Value() int { return c.value }
+
+// doublingCounter looks like an incrementer but silently doubles every
+// addition - the Liskov violation this test expects Substitutable to catch.
+type doublingCounter struct {
+	value int
+}
+
+func (c *doublingCounter) Add(n int)   { c.value += n * 2 }
+func (c *doublingCounter) Value() int { return c.value }
+
+func TestPrePost(t *testing.T) {
+	c := For[incrementer]().
+		PrePost("adding 5 increases the value by 5", func(item incrementer) {
+			item.Add(5)
+		}, func(item incrementer) bool {
+			return item.Value() == 5
+		})
+
+	c.Check(t, func() incrementer { return &counter{} })
+}
+
+func TestHistoryInvariant(t *testing.T) {
+	ops := []func(incrementer){
+		func(i incrementer) { i.Add(1) },
+		func(i incrementer) { i.Add(2) },
+		func(i incrementer) { i.Add(3) },
+	}
+
+	c := For[incrementer]().
+		HistoryInvariant("value after +1, +2, +3 is 6", ops, func(item incrementer) bool {
+			return item.Value() == 6
+		})
+
+	c.Check(t, func() incrementer { return &counter{} })
+}
+
+func TestRandomCheck(t *testing.T) {
+	c := For[incrementer]().
+		Invariant("value is never negative", func(item incrementer) bool {
+			return item.Value() >= 0
+		})
+
+	c.RandomCheck(t, 42, 20, func(rng *rand.Rand) incrementer {
+		return &counter{value: rng.Intn(100)}
+	})
+}
+
+func TestSubstitutableCatchesAHistoryViolation(t *testing.T) {
+	ops := []func(incrementer){
+		func(i incrementer) { i.Add(1) },
+		func(i incrementer) { i.Add(2) },
+		func(i incrementer) { i.Add(3) },
+	}
+
+	base := For[incrementer]().
+		HistoryInvariant("value after +1, +2, +3 is 6", ops, func(item incrementer) bool {
+			return item.Value() == 6
+		})
+
+	// The base factory satisfies its own history invariant...
+	base.Check(t, func() incrementer { return &counter{} })
+
+	// ...but doublingCounter does not, which Substitutable must catch. Use a
+	// Recorder rather than t itself so the expected violation doesn't fail
+	// this test.
+	recorder := &Recorder{}
+	Substitutable(recorder, base, func() incrementer { return &doublingCounter{} })
+
+	if !recorder.Failed() {
+		t.Fatal("expected doublingCounter to violate the history invariant, but it didn't")
+	}
+}
@@ -1,6 +1,13 @@
 package main
 
-import "fmt"
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
 
 /*
 		The Dependency Inversion Principle (DIP)
@@ -26,15 +33,17 @@ import "fmt"
 type RegularWorker struct {
 }
 
-func (w *RegularWorker) Work() {
+func (w *RegularWorker) Work(ctx context.Context) error {
 	fmt.Println("Working...")
+	return nil
 }
 
 type SpecialWorker struct {
 }
 
-func (w *SpecialWorker) Work() {
+func (w *SpecialWorker) Work(ctx context.Context) error {
 	fmt.Println("Especially working...")
+	return nil
 }
 
 type SpecificManager struct {
@@ -44,11 +53,11 @@ type SpecificManager struct {
 
 func (m *SpecificManager) DelegateWork() {
 	for _, w := range m.regularWorkers {
-		w.Work()
+		w.Work(context.Background())
 	}
 
 	for _, s := range m.specialWorkers {
-		s.Work()
+		s.Work(context.Background())
 	}
 }
 
@@ -61,22 +70,294 @@ func (m *SpecificManager) AddSpecialWorker(w *SpecialWorker) {
 }
 
 // !!! The right way
+//
+// Manager used to just loop over a slice of IWorker and call Work on each.
+// It now dispatches IWorker jobs across a bounded pool of goroutines, with
+// priorities, retries and backpressure - but it still only ever depends on
+// the IWorker abstraction. Swapping this naive pool for a production-grade
+// executor later would not require touching a single worker implementation.
 type IWorker interface {
-	Work()
+	Work(ctx context.Context) error
 }
 
+// Priority controls the order in which queued jobs are picked up. Higher
+// priority jobs are always dispatched before lower priority ones; jobs of
+// equal priority are dispatched in submission order.
+type Priority int
+
+const (
+	Low Priority = iota
+	Normal
+	High
+)
+
+// BackpressurePolicy decides what Submit does when the queue is full.
+type BackpressurePolicy int
+
+const (
+	// PolicyBlock makes Submit wait until space frees up in the queue.
+	PolicyBlock BackpressurePolicy = iota
+	// PolicyError makes Submit return ErrQueueFull immediately.
+	PolicyError
+)
+
+// ErrQueueFull is returned by Submit when PolicyError is in effect and the
+// queue has no room left.
+var ErrQueueFull = errors.New("dip: manager queue is full")
+
+// ErrManagerClosed is returned by Submit once Shutdown has been called; the
+// manager no longer has any workers left to dequeue and run jobs.
+var ErrManagerClosed = errors.New("dip: manager is closed")
+
+// Job pairs a unit of work with the priority it was submitted at.
+type Job struct {
+	Worker   IWorker
+	Priority Priority
+}
+
+// jobItem is the internal heap element: it adds a monotonic sequence number
+// so jobs of equal priority stay in FIFO order.
+type jobItem struct {
+	job   Job
+	seq   int64
+	index int
+}
+
+type jobQueue []*jobItem
+
+func (q jobQueue) Len() int { return len(q) }
+
+func (q jobQueue) Less(i, j int) bool {
+	if q[i].job.Priority != q[j].job.Priority {
+		return q[i].job.Priority > q[j].job.Priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q jobQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *jobQueue) Push(x any) {
+	item := x.(*jobItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *jobQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// Stats is a point-in-time snapshot of the Manager's dispatch state.
+type Stats struct {
+	QueueDepth int
+	InFlight   int
+	Completed  int64
+}
+
+// ManagerConfig configures the worker pool a Manager dispatches jobs onto.
+type ManagerConfig struct {
+	// Workers is how many goroutines pull jobs off the queue concurrently.
+	Workers int
+	// QueueCapacity bounds how many jobs may be waiting at once.
+	QueueCapacity int
+	// Policy decides what Submit does once QueueCapacity is reached.
+	Policy BackpressurePolicy
+	// MaxRetries is how many additional attempts a failing job gets.
+	MaxRetries int
+	// BaseBackoff is the delay before the first retry; it doubles on every
+	// subsequent attempt.
+	BaseBackoff time.Duration
+
+	// OnStart, OnComplete and OnError are optional lifecycle hooks.
+	OnStart    func(Job)
+	OnComplete func(Job)
+	OnError    func(Job, error)
+}
+
+// Manager is the DIP-friendly dispatch subsystem: it depends only on
+// IWorker, so any production-grade executor can stand in for it without the
+// workers ever knowing the difference.
 type Manager struct {
-	workers []IWorker
+	cfg ManagerConfig
+
+	mu      sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	queue    jobQueue
+	seq      int64
+	inFlight int
+	completed int64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewManager builds a Manager and starts its worker pool immediately.
+func NewManager(cfg ManagerConfig) *Manager {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueCapacity <= 0 {
+		cfg.QueueCapacity = 64
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 50 * time.Millisecond
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Manager{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	m.notEmpty = sync.NewCond(&m.mu)
+	m.notFull = sync.NewCond(&m.mu)
+
+	heap.Init(&m.queue)
+
+	for i := 0; i < cfg.Workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+
+	return m
+}
+
+// Submit queues a job at the given priority. If the queue is full, behavior
+// depends on ManagerConfig.Policy: PolicyBlock waits for room, PolicyError
+// returns ErrQueueFull immediately.
+func (m *Manager) Submit(worker IWorker, priority Priority) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ctx.Err() != nil {
+		return ErrManagerClosed
+	}
+
+	for len(m.queue) >= m.cfg.QueueCapacity {
+		if m.cfg.Policy == PolicyError {
+			return ErrQueueFull
+		}
+		m.notFull.Wait()
+		if m.ctx.Err() != nil {
+			return ErrManagerClosed
+		}
+	}
+
+	m.seq++
+	heap.Push(&m.queue, &jobItem{job: Job{Worker: worker, Priority: priority}, seq: m.seq})
+	m.notEmpty.Signal()
+	return nil
+}
+
+// AddWorker submits a worker at Normal priority, matching the old
+// fire-and-forget style for callers that don't care about prioritization.
+func (m *Manager) AddWorker(worker IWorker) error {
+	return m.Submit(worker, Normal)
+}
+
+func (m *Manager) worker() {
+	defer m.wg.Done()
+
+	for {
+		job, ok := m.dequeue()
+		if !ok {
+			return
+		}
+		m.run(job)
+	}
+}
+
+func (m *Manager) dequeue() (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for len(m.queue) == 0 {
+		if m.ctx.Err() != nil {
+			return Job{}, false
+		}
+		m.notEmpty.Wait()
+	}
+
+	item := heap.Pop(&m.queue).(*jobItem)
+	m.inFlight++
+	m.notFull.Signal()
+	return item.job, true
+}
+
+func (m *Manager) run(job Job) {
+	if m.cfg.OnStart != nil {
+		m.cfg.OnStart(job)
+	}
+
+	var err error
+	backoff := m.cfg.BaseBackoff
+	for attempt := 0; attempt <= m.cfg.MaxRetries; attempt++ {
+		err = job.Worker.Work(m.ctx)
+		if err == nil {
+			break
+		}
+		if m.ctx.Err() != nil {
+			break
+		}
+		if attempt < m.cfg.MaxRetries {
+			select {
+			case <-time.After(backoff):
+			case <-m.ctx.Done():
+			}
+			backoff *= 2
+		}
+	}
+
+	m.mu.Lock()
+	m.inFlight--
+	m.completed++
+	m.mu.Unlock()
+
+	if err != nil {
+		if m.cfg.OnError != nil {
+			m.cfg.OnError(job, err)
+		}
+		return
+	}
+	if m.cfg.OnComplete != nil {
+		m.cfg.OnComplete(job)
+	}
 }
 
-func (m *Manager) DelegateWork() {
-	for _, w := range m.workers {
-		w.Work()
+// Stats reports the current queue depth, in-flight job count, and the total
+// number of jobs completed (successfully or not) so far.
+func (m *Manager) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return Stats{
+		QueueDepth: len(m.queue),
+		InFlight:   m.inFlight,
+		Completed:  m.completed,
 	}
 }
 
-func (m *Manager) AddWorker(w IWorker) {
-	m.workers = append(m.workers, w)
+// Shutdown cancels any in-flight retries and waits for every worker
+// goroutine to drain and exit.
+func (m *Manager) Shutdown() {
+	m.cancel()
+
+	m.mu.Lock()
+	m.notEmpty.Broadcast()
+	m.notFull.Broadcast()
+	m.mu.Unlock()
+
+	m.wg.Wait()
 }
 
 func main() {
@@ -90,8 +371,21 @@ func main() {
 	fmt.Println()
 	fmt.Println("The right way")
 
-	manager := &Manager{}
-	manager.AddWorker(&RegularWorker{})
-	manager.AddWorker(&SpecialWorker{})
-	manager.DelegateWork()
+	manager := NewManager(ManagerConfig{
+		Workers:       2,
+		QueueCapacity: 10,
+		Policy:        PolicyBlock,
+		MaxRetries:    2,
+		OnError: func(job Job, err error) {
+			fmt.Println("job failed after retries:", err)
+		},
+	})
+
+	manager.Submit(&RegularWorker{}, Normal)
+	manager.Submit(&SpecialWorker{}, High)
+
+	time.Sleep(100 * time.Millisecond)
+	fmt.Printf("stats: %+v\n", manager.Stats())
+
+	manager.Shutdown()
 }
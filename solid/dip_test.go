@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingWorker appends its label to order (under mu) every time it runs,
+// optionally failing a fixed number of times before succeeding.
+type recordingWorker struct {
+	label      string
+	mu         *sync.Mutex
+	order      *[]string
+	failTimes  int
+	ran        int32
+}
+
+func (w *recordingWorker) Work(ctx context.Context) error {
+	w.mu.Lock()
+	*w.order = append(*w.order, w.label)
+	w.mu.Unlock()
+
+	n := atomic.AddInt32(&w.ran, 1)
+	if int(n) <= w.failTimes {
+		return errors.New("simulated failure")
+	}
+	return nil
+}
+
+func TestManagerDispatchesHigherPriorityFirst(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+
+	// A single worker goroutine makes dispatch order deterministic: every
+	// job must be queued before the worker is free to pick the next one, so
+	// occupy it with a blocking first job, then submit the rest while it's in
+	// flight.
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	first := &recordingWorker{label: "first", mu: &mu, order: &order}
+	blocking := workerFunc(func(ctx context.Context) error {
+		close(started)
+		<-gate
+		return first.Work(ctx)
+	})
+
+	m := NewManager(ManagerConfig{Workers: 1, QueueCapacity: 10, Policy: PolicyError})
+	defer m.Shutdown()
+
+	if err := m.Submit(blocking, Normal); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started // the single worker must already be running blocking before the rest queue up
+
+	low := &recordingWorker{label: "low", mu: &mu, order: &order}
+	high := &recordingWorker{label: "high", mu: &mu, order: &order}
+	normal := &recordingWorker{label: "normal", mu: &mu, order: &order}
+
+	if err := m.Submit(low, Low); err != nil {
+		t.Fatalf("Submit low: %v", err)
+	}
+	if err := m.Submit(high, High); err != nil {
+		t.Fatalf("Submit high: %v", err)
+	}
+	if err := m.Submit(normal, Normal); err != nil {
+		t.Fatalf("Submit normal: %v", err)
+	}
+
+	close(gate)
+
+	deadline := time.After(time.Second)
+	for {
+		mu.Lock()
+		done := len(order) == 4
+		mu.Unlock()
+		if done {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for jobs to run")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	mu.Lock()
+	got := append([]string(nil), order...)
+	mu.Unlock()
+
+	want := []string{"first", "high", "normal", "low"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("dispatch order = %v, want %v", got, want)
+		}
+	}
+}
+
+// workerFunc adapts a plain function to IWorker for tests that need
+// ad-hoc behavior without declaring a new named type.
+type workerFunc func(ctx context.Context) error
+
+func (f workerFunc) Work(ctx context.Context) error { return f(ctx) }
+
+func TestManagerPolicyErrorRejectsWhenFull(t *testing.T) {
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	blocker := workerFunc(func(ctx context.Context) error {
+		close(started)
+		<-gate
+		return nil
+	})
+
+	m := NewManager(ManagerConfig{Workers: 1, QueueCapacity: 1, Policy: PolicyError})
+	defer func() {
+		close(gate)
+		m.Shutdown()
+	}()
+
+	if err := m.Submit(blocker, Normal); err != nil {
+		t.Fatalf("first Submit: %v", err)
+	}
+	<-started // the single worker must have dequeued blocker before we rely on queue depth
+
+	if err := m.Submit(workerFunc(func(context.Context) error { return nil }), Normal); err != nil {
+		t.Fatalf("second Submit (should fill the queue): %v", err)
+	}
+	if err := m.Submit(workerFunc(func(context.Context) error { return nil }), Normal); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+}
+
+func TestManagerPolicyBlockWaitsForRoom(t *testing.T) {
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	blocker := workerFunc(func(ctx context.Context) error {
+		close(started)
+		<-gate
+		return nil
+	})
+
+	m := NewManager(ManagerConfig{Workers: 1, QueueCapacity: 1, Policy: PolicyBlock})
+	defer m.Shutdown()
+
+	if err := m.Submit(blocker, Normal); err != nil {
+		t.Fatalf("first Submit: %v", err)
+	}
+	<-started // the single worker must have dequeued blocker before we rely on queue depth
+
+	if err := m.Submit(workerFunc(func(context.Context) error { return nil }), Normal); err != nil {
+		t.Fatalf("second Submit: %v", err)
+	}
+
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- m.Submit(workerFunc(func(context.Context) error { return nil }), Normal)
+	}()
+
+	select {
+	case <-submitted:
+		t.Fatal("Submit should have blocked while the queue was full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(gate)
+
+	select {
+	case err := <-submitted:
+		if err != nil {
+			t.Fatalf("blocked Submit returned an error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Submit never unblocked after room freed up")
+	}
+}
+
+func TestManagerRetriesOnFailure(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+
+	onErrorCalls := int32(0)
+	onCompleteCalls := int32(0)
+
+	m := NewManager(ManagerConfig{
+		Workers:       1,
+		QueueCapacity: 4,
+		MaxRetries:    2,
+		BaseBackoff:   time.Millisecond,
+		OnError:       func(Job, error) { atomic.AddInt32(&onErrorCalls, 1) },
+		OnComplete:    func(Job) { atomic.AddInt32(&onCompleteCalls, 1) },
+	})
+	defer m.Shutdown()
+
+	w := &recordingWorker{label: "retry", mu: &mu, order: &order, failTimes: 2}
+	if err := m.Submit(w, Normal); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	deadline := time.After(time.Second)
+	for atomic.LoadInt32(&onCompleteCalls) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for the job to eventually succeed")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	if atomic.LoadInt32(&w.ran) != 3 {
+		t.Fatalf("expected 3 attempts (2 failures + 1 success), got %d", w.ran)
+	}
+	if atomic.LoadInt32(&onErrorCalls) != 0 {
+		t.Fatalf("OnError should not fire once a retry succeeds, got %d calls", onErrorCalls)
+	}
+}
+
+func TestManagerGivesUpAfterMaxRetries(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+
+	onErrorCalls := int32(0)
+
+	m := NewManager(ManagerConfig{
+		Workers:       1,
+		QueueCapacity: 4,
+		MaxRetries:    1,
+		BaseBackoff:   time.Millisecond,
+		OnError:       func(Job, error) { atomic.AddInt32(&onErrorCalls, 1) },
+	})
+	defer m.Shutdown()
+
+	w := &recordingWorker{label: "always-fails", mu: &mu, order: &order, failTimes: 10}
+	if err := m.Submit(w, Normal); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	deadline := time.After(time.Second)
+	for atomic.LoadInt32(&onErrorCalls) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for OnError")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	if atomic.LoadInt32(&w.ran) != 2 {
+		t.Fatalf("expected 2 attempts (1 initial + 1 retry), got %d", w.ran)
+	}
+}
+
+func TestManagerSubmitAfterShutdownIsRejected(t *testing.T) {
+	m := NewManager(ManagerConfig{Workers: 1, QueueCapacity: 4})
+	m.Shutdown()
+
+	err := m.Submit(workerFunc(func(context.Context) error { return nil }), Normal)
+	if !errors.Is(err, ErrManagerClosed) {
+		t.Fatalf("expected ErrManagerClosed, got %v", err)
+	}
+
+	stats := m.Stats()
+	if stats.QueueDepth != 0 {
+		t.Fatalf("rejected job should not sit in the queue, got depth %d", stats.QueueDepth)
+	}
+}
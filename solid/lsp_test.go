@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/sebastianplesciuc/golang-design-patterns/solid/contract"
+)
+
+// rectangleContract captures the behavior any IRectangle is expected to
+// uphold. Square is the textbook LSP violation: it cannot honor
+// "width preserved after SetHeight" and "height preserved after SetWidth"
+// because it is forced to keep both sides equal.
+var rectangleContract = contract.For[IRectangle]().
+	Invariant("width preserved after SetHeight", func(r IRectangle) bool {
+		width := r.GetWidth()
+		r.SetHeight(20)
+		return r.GetWidth() == width
+	}).
+	Invariant("height preserved after SetWidth", func(r IRectangle) bool {
+		height := r.GetHeight()
+		r.SetWidth(20)
+		return r.GetHeight() == height
+	}).
+	Invariant("area matches width times height", func(r IRectangle) bool {
+		return r.Area() == r.GetWidth()*r.GetHeight()
+	})
+
+func TestRectangleSatisfiesItsOwnContract(t *testing.T) {
+	rectangleContract.Check(t, func() IRectangle {
+		return NewRectangle(10, 5)
+	})
+}
+
+func TestSquareViolatesLiskovSubstitution(t *testing.T) {
+	recorder := &contract.Recorder{}
+	contract.Substitutable(recorder, rectangleContract, func() IRectangle {
+		return NewSquare(10)
+	})
+
+	if !recorder.Failed() {
+		t.Fatal("expected Square to violate the rectangle contract, but it didn't")
+	}
+}
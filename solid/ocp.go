@@ -1,6 +1,7 @@
 package main
 
 import (
+	"cmp"
 	"fmt"
 )
 
@@ -99,60 +100,182 @@ func (pf *WrongFilter) BySizeAndColor(products []*Product, size Size, color Colo
 	return result
 }
 
-// !!! The better way
-type ISpecification interface {
-	IsSatisfied(*Product) bool
+// !!! The better way. Specification and Filter are generic, so the same
+// infrastructure filters products, users, orders, or anything else without
+// ever being touched again.
+type Specification[T any] interface {
+	IsSatisfied(T) bool
 }
 
-type ColorSpecification struct {
-	color Color
+// Predicate adapts a plain func(T) bool into a Specification[T].
+type Predicate[T any] func(T) bool
+
+func (p Predicate[T]) IsSatisfied(item T) bool {
+	return p(item)
 }
 
-func (cs *ColorSpecification) IsSatisfied(product *Product) bool {
-	return cs.color == product.color
+// And is satisfied when both specifications are.
+func And[T any](first, second Specification[T]) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return first.IsSatisfied(item) && second.IsSatisfied(item)
+	})
 }
 
-func NewColorSpecification(color Color) ISpecification {
-	return &ColorSpecification{color: color}
+// Or is satisfied when either specification is.
+func Or[T any](first, second Specification[T]) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return first.IsSatisfied(item) || second.IsSatisfied(item)
+	})
 }
 
-type SizeSpecification struct {
-	size Size
+// Not inverts a specification.
+func Not[T any](spec Specification[T]) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return !spec.IsSatisfied(item)
+	})
 }
 
-func (cs *SizeSpecification) IsSatisfied(product *Product) bool {
-	return cs.size == product.size
+// Xor is satisfied when exactly one of the two specifications is.
+func Xor[T any](first, second Specification[T]) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return first.IsSatisfied(item) != second.IsSatisfied(item)
+	})
 }
 
-func NewSizeSpecification(size Size) ISpecification {
-	return &SizeSpecification{size: size}
+// All is satisfied when every specification in specs is.
+func All[T any](specs ...Specification[T]) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		for _, spec := range specs {
+			if !spec.IsSatisfied(item) {
+				return false
+			}
+		}
+		return true
+	})
 }
 
-type AndSpecification struct {
-	first  ISpecification
-	second ISpecification
+// Any is satisfied when at least one specification in specs is.
+func Any[T any](specs ...Specification[T]) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		for _, spec := range specs {
+			if spec.IsSatisfied(item) {
+				return true
+			}
+		}
+		return false
+	})
 }
 
-func (as *AndSpecification) IsSatisfied(product *Product) bool {
-	return as.first.IsSatisfied(product) && as.second.IsSatisfied(product)
+// Field wraps an accessor so comparison specifications can be built without
+// writing a bespoke *Specification type per field.
+type Field[T any, V comparable] struct {
+	get func(T) V
 }
 
-func NewAndSpecification(first, second ISpecification) ISpecification {
-	return &AndSpecification{first: first, second: second}
+func FieldOf[T any, V comparable](get func(T) V) Field[T, V] {
+	return Field[T, V]{get: get}
 }
 
-type IFilter interface {
-	Filter([]*Product, ISpecification) []*Product
+// Eq builds a Specification[T] satisfied when the field equals want.
+func (f Field[T, V]) Eq(want V) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return f.get(item) == want
+	})
 }
 
-type RightFilter struct{}
+// In builds a Specification[T] satisfied when the field matches any of options.
+func (f Field[T, V]) In(options ...V) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		value := f.get(item)
+		for _, option := range options {
+			if value == option {
+				return true
+			}
+		}
+		return false
+	})
+}
 
-func (rf *RightFilter) Filter(products []*Product, specification ISpecification) []*Product {
-	result := []*Product{}
+// OrderedField is a Field over a type that also supports <, <= etc.
+type OrderedField[T any, V cmp.Ordered] struct {
+	get func(T) V
+}
 
-	for _, product := range products {
-		if specification.IsSatisfied(product) {
-			result = append(result, product)
+func OrderedFieldOf[T any, V cmp.Ordered](get func(T) V) OrderedField[T, V] {
+	return OrderedField[T, V]{get: get}
+}
+
+func (f OrderedField[T, V]) Eq(want V) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return f.get(item) == want
+	})
+}
+
+func (f OrderedField[T, V]) Lt(want V) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return f.get(item) < want
+	})
+}
+
+func (f OrderedField[T, V]) Gt(want V) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		return f.get(item) > want
+	})
+}
+
+func (f OrderedField[T, V]) Between(low, high V) Specification[T] {
+	return Predicate[T](func(item T) bool {
+		value := f.get(item)
+		return value >= low && value <= high
+	})
+}
+
+// SpecBuilder gives callers a fluent way to assemble a Specification[T]
+// without nesting And/Or calls by hand.
+type SpecBuilder[T any] struct {
+	spec Specification[T]
+}
+
+func Spec[T any]() *SpecBuilder[T] {
+	return &SpecBuilder[T]{}
+}
+
+func (b *SpecBuilder[T]) Where(spec Specification[T]) *SpecBuilder[T] {
+	b.spec = spec
+	return b
+}
+
+func (b *SpecBuilder[T]) And(spec Specification[T]) *SpecBuilder[T] {
+	b.spec = And(b.spec, spec)
+	return b
+}
+
+func (b *SpecBuilder[T]) Or(spec Specification[T]) *SpecBuilder[T] {
+	b.spec = Or(b.spec, spec)
+	return b
+}
+
+func (b *SpecBuilder[T]) Build() Specification[T] {
+	return b.spec
+}
+
+// sizeField and colorField let callers write sizeField.Eq(Large) instead of
+// hand-rolling a *SizeSpecification for every new comparison.
+var sizeField = OrderedFieldOf(func(p *Product) Size { return p.size })
+var colorField = FieldOf(func(p *Product) Color { return p.color })
+
+type IFilter[T any] interface {
+	Filter([]T, Specification[T]) []T
+}
+
+type RightFilter[T any] struct{}
+
+func (rf *RightFilter[T]) Filter(items []T, specification Specification[T]) []T {
+	result := []T{}
+
+	for _, item := range items {
+		if specification.IsSatisfied(item) {
+			result = append(result, item)
 		}
 	}
 
@@ -195,31 +318,68 @@ func main() {
 	fmt.Println()
 	fmt.Println()
 	fmt.Println("--The right way to filter things...")
-	rightFilter := &RightFilter{}
+	rightFilter := &RightFilter[*Product]{}
 
 	fmt.Println()
 	fmt.Println("--Small things:")
-	smallSpecification := NewSizeSpecification(Small)
-	smallThings = rightFilter.Filter(products, smallSpecification)
+	smallThings = rightFilter.Filter(products, sizeField.Eq(Small))
 	for _, thing := range smallThings {
 		fmt.Println(thing.GetName())
 	}
 
 	fmt.Println()
 	fmt.Println("--Green things:")
-	greenSpecification := NewColorSpecification(Green)
-	greenThings = rightFilter.Filter(products, greenSpecification)
+	greenThings = rightFilter.Filter(products, colorField.Eq(Green))
 	for _, thing := range greenThings {
 		fmt.Println(thing.GetName())
 	}
 
 	fmt.Println()
-	fmt.Println("--Large and yellow things:")
-	yellowSpecification := NewColorSpecification(Yellow)
-	largeSpecification := NewSizeSpecification(Large)
-	largeYellowThingsSpecification := NewAndSpecification(largeSpecification, yellowSpecification)
-	largeYellowThings = rightFilter.Filter(products, largeYellowThingsSpecification)
+	fmt.Println("--Large, red or yellow things, built fluently:")
+	largeRedOrYellow := Spec[*Product]().
+		Where(sizeField.Eq(Large)).
+		And(colorField.In(Red, Yellow)).
+		Build()
+	largeYellowThings = rightFilter.Filter(products, largeRedOrYellow)
 	for _, thing := range largeYellowThings {
 		fmt.Println(thing.GetName())
 	}
+
+	fmt.Println()
+	fmt.Println("--The same infrastructure filtering unrelated slices...")
+
+	type User struct {
+		name string
+		age  int
+	}
+	users := []User{{"Ana", 17}, {"Bob", 34}, {"Cora", 65}}
+	age := OrderedFieldOf(func(u User) int { return u.age })
+	userFilter := &RightFilter[User]{}
+	for _, adult := range userFilter.Filter(users, age.Between(18, 64)) {
+		fmt.Println(adult.name)
+	}
+
+	type Order struct {
+		id     string
+		status string
+	}
+	orders := []Order{{"A1", "pending"}, {"A2", "shipped"}, {"A3", "cancelled"}}
+	status := FieldOf(func(o Order) string { return o.status })
+	openOrders := All[Order](Not(status.Eq("cancelled")), Not(status.Eq("shipped")))
+	orderFilter := &RightFilter[Order]{}
+	for _, order := range orderFilter.Filter(orders, openOrders) {
+		fmt.Println(order.id)
+	}
+
+	fmt.Println()
+	fmt.Println("--Filtering products from a runtime query string...")
+	query := "size == Large && (color == Yellow || color == Red)"
+	querySpec, err := Compile[*Product](query, ProductFieldResolver{})
+	if err != nil {
+		fmt.Println("failed to compile query:", err)
+		return
+	}
+	for _, thing := range rightFilter.Filter(products, querySpec) {
+		fmt.Println(thing.GetName())
+	}
 }
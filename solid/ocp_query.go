@@ -0,0 +1,604 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+	A small query language on top of the OCP specifications.
+
+	Strings such as `size == Large && (color == Yellow || color == Red)` are
+	lexed, parsed into an AST, and then compiled into the same
+	Specification[T] tree RightFilter already knows how to consume. This lets
+	callers express filters at runtime - from a config file or an HTTP query
+	string - without recompiling the program.
+*/
+
+// ParseError reports where in the query string something went wrong, so a
+// caller surfacing it to a user (or a config file) can point at the mistake.
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("query: %s (at position %d)", e.Msg, e.Pos)
+}
+
+// FieldResolver lets a caller plug in their own struct as the subject of a
+// query. Fields declares every queryable field and its kind, which Compile
+// uses to reject unknown identifiers and type mismatches before ever
+// evaluating an item. Resolve extracts a field's value from an item, and
+// Constant resolves a bare identifier used as a literal (an enum value such
+// as Large or Yellow) to the same comparable representation.
+type FieldResolver[T any] interface {
+	Fields() map[string]reflect.Kind
+	Resolve(item T, field string) any
+	Constant(name string) (any, bool)
+}
+
+// tokenKind enumerates every token the lexer can produce.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokAnd
+	tokOr
+	tokNot
+	tokEq
+	tokNeq
+	tokLt
+	tokLe
+	tokGt
+	tokGe
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lexer turns a query string into a flat token stream.
+type lexer struct {
+	input string
+	pos   int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: input}
+}
+
+func (l *lexer) peekByte() byte {
+	if l.pos >= len(l.input) {
+		return 0
+	}
+	return l.input[l.pos]
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && (l.input[l.pos] == ' ' || l.input[l.pos] == '\t' || l.input[l.pos] == '\n') {
+		l.pos++
+	}
+
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	c := l.input[l.pos]
+
+	switch {
+	case c == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start}, nil
+	case c == '&':
+		if l.pos+1 < len(l.input) && l.input[l.pos+1] == '&' {
+			l.pos += 2
+			return token{kind: tokAnd, text: "&&", pos: start}, nil
+		}
+		return token{}, &ParseError{Pos: start, Msg: "expected '&&'"}
+	case c == '|':
+		if l.pos+1 < len(l.input) && l.input[l.pos+1] == '|' {
+			l.pos += 2
+			return token{kind: tokOr, text: "||", pos: start}, nil
+		}
+		return token{}, &ParseError{Pos: start, Msg: "expected '||'"}
+	case c == '!':
+		l.pos++
+		if l.peekByte() == '=' {
+			l.pos++
+			return token{kind: tokNeq, text: "!=", pos: start}, nil
+		}
+		return token{kind: tokNot, text: "!", pos: start}, nil
+	case c == '=':
+		l.pos++
+		if l.peekByte() == '=' {
+			l.pos++
+			return token{kind: tokEq, text: "==", pos: start}, nil
+		}
+		return token{}, &ParseError{Pos: start, Msg: "expected '=='"}
+	case c == '<':
+		l.pos++
+		if l.peekByte() == '=' {
+			l.pos++
+			return token{kind: tokLe, text: "<=", pos: start}, nil
+		}
+		return token{kind: tokLt, text: "<", pos: start}, nil
+	case c == '>':
+		l.pos++
+		if l.peekByte() == '=' {
+			l.pos++
+			return token{kind: tokGe, text: ">=", pos: start}, nil
+		}
+		return token{kind: tokGt, text: ">", pos: start}, nil
+	case c == '"':
+		l.pos++
+		for l.pos < len(l.input) && l.input[l.pos] != '"' {
+			l.pos++
+		}
+		if l.pos >= len(l.input) {
+			return token{}, &ParseError{Pos: start, Msg: "unterminated string literal"}
+		}
+		text := l.input[start+1 : l.pos]
+		l.pos++
+		return token{kind: tokString, text: text, pos: start}, nil
+	case c >= '0' && c <= '9':
+		for l.pos < len(l.input) && (isDigit(l.input[l.pos]) || l.input[l.pos] == '.') {
+			l.pos++
+		}
+		return token{kind: tokNumber, text: l.input[start:l.pos], pos: start}, nil
+	case isIdentStart(c):
+		for l.pos < len(l.input) && isIdentPart(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokIdent, text: l.input[start:l.pos], pos: start}, nil
+	default:
+		return token{}, &ParseError{Pos: start, Msg: fmt.Sprintf("unexpected character %q", c)}
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+// expr is a node in the parsed query AST, independent of any concrete T.
+type expr interface {
+	exprNode()
+}
+
+type andExpr struct{ left, right expr }
+type orExpr struct{ left, right expr }
+type notExpr struct{ operand expr }
+
+type comparisonExpr struct {
+	field   string
+	op      tokenKind
+	literal token
+	pos     int
+}
+
+func (andExpr) exprNode()        {}
+func (orExpr) exprNode()         {}
+func (notExpr) exprNode()        {}
+func (comparisonExpr) exprNode() {}
+
+// parser is a hand-written recursive-descent parser. Precedence, low to
+// high: ||, &&, unary !, comparisons and parenthesized groups.
+type parser struct {
+	lex *lexer
+	cur token
+}
+
+func newParser(query string) (*parser, error) {
+	p := &parser{lex: newLexer(query)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.cur = tok
+	return nil
+}
+
+func (p *parser) parse() (expr, error) {
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.cur.kind != tokEOF {
+		return nil, &ParseError{Pos: p.cur.pos, Msg: fmt.Sprintf("unexpected token %q", p.cur.text)}
+	}
+	return e, nil
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur.kind == tokOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur.kind == tokAnd {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.cur.kind == tokNot {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	switch p.cur.kind {
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.cur.kind != tokRParen {
+			return nil, &ParseError{Pos: p.cur.pos, Msg: "expected ')'"}
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case tokIdent:
+		return p.parseComparison()
+	default:
+		return nil, &ParseError{Pos: p.cur.pos, Msg: fmt.Sprintf("unexpected token %q", p.cur.text)}
+	}
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	field := p.cur
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	switch p.cur.kind {
+	case tokEq, tokNeq, tokLt, tokLe, tokGt, tokGe:
+	default:
+		return nil, &ParseError{Pos: p.cur.pos, Msg: "expected a comparison operator"}
+	}
+	op := p.cur.kind
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	switch p.cur.kind {
+	case tokIdent, tokNumber, tokString:
+	default:
+		return nil, &ParseError{Pos: p.cur.pos, Msg: "expected a literal value"}
+	}
+	literal := p.cur
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	return comparisonExpr{field: field.text, op: op, literal: literal, pos: field.pos}, nil
+}
+
+// Compile parses query and walks the resulting AST into a Specification[T],
+// using resolver to validate field names and translate literals into
+// comparable values.
+func Compile[T any](query string, resolver FieldResolver[T]) (Specification[T], error) {
+	p, err := newParser(query)
+	if err != nil {
+		return nil, err
+	}
+
+	ast, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return compileNode(ast, resolver)
+}
+
+func compileNode[T any](node expr, resolver FieldResolver[T]) (Specification[T], error) {
+	switch n := node.(type) {
+	case andExpr:
+		left, err := compileNode[T](n.left, resolver)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileNode[T](n.right, resolver)
+		if err != nil {
+			return nil, err
+		}
+		return &AndSpecification[T]{First: left, Second: right}, nil
+	case orExpr:
+		left, err := compileNode[T](n.left, resolver)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileNode[T](n.right, resolver)
+		if err != nil {
+			return nil, err
+		}
+		return &OrSpecification[T]{First: left, Second: right}, nil
+	case notExpr:
+		operand, err := compileNode[T](n.operand, resolver)
+		if err != nil {
+			return nil, err
+		}
+		return &NotSpecification[T]{Operand: operand}, nil
+	case comparisonExpr:
+		return compileComparison(n, resolver)
+	default:
+		return nil, &ParseError{Msg: "unknown expression node"}
+	}
+}
+
+func compileComparison[T any](n comparisonExpr, resolver FieldResolver[T]) (Specification[T], error) {
+	kind, ok := resolver.Fields()[n.field]
+	if !ok {
+		return nil, &ParseError{Pos: n.pos, Msg: fmt.Sprintf("unknown field %q", n.field)}
+	}
+
+	literal, err := literalValue(n.literal, kind, resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ComparisonSpecification[T]{
+		Resolver: resolver,
+		Field:    n.field,
+		Op:       n.op,
+		Literal:  literal,
+	}, nil
+}
+
+func literalValue[T any](tok token, kind reflect.Kind, resolver FieldResolver[T]) (any, error) {
+	switch tok.kind {
+	case tokIdent:
+		if value, ok := resolver.Constant(tok.text); ok {
+			if got := reflect.ValueOf(value).Kind(); got != kind {
+				return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("type mismatch: field expects a %s, got constant %s of kind %s", kind, tok.text, got)}
+			}
+			return value, nil
+		}
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("unknown identifier %q", tok.text)}
+	case tokString:
+		if kind != reflect.String {
+			return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("type mismatch: field expects a %s, got string literal %q", kind, tok.text)}
+		}
+		return tok.text, nil
+	case tokNumber:
+		if kind == reflect.Float64 {
+			value, err := strconv.ParseFloat(tok.text, 64)
+			if err != nil {
+				return nil, &ParseError{Pos: tok.pos, Msg: "invalid number literal"}
+			}
+			return value, nil
+		}
+		if kind == reflect.Int {
+			value, err := strconv.Atoi(tok.text)
+			if err != nil {
+				return nil, &ParseError{Pos: tok.pos, Msg: "invalid integer literal"}
+			}
+			return value, nil
+		}
+		return nil, &ParseError{Pos: tok.pos, Msg: fmt.Sprintf("type mismatch: field expects a %s, got a number literal", kind)}
+	default:
+		return nil, &ParseError{Pos: tok.pos, Msg: "invalid literal"}
+	}
+}
+
+// AndSpecification, OrSpecification, NotSpecification and
+// ComparisonSpecification are the concrete Specification[T] nodes Compile
+// produces; they are exported so other callers can build query trees by
+// hand instead of going through the parser.
+type AndSpecification[T any] struct {
+	First, Second Specification[T]
+}
+
+func (s *AndSpecification[T]) IsSatisfied(item T) bool {
+	return s.First.IsSatisfied(item) && s.Second.IsSatisfied(item)
+}
+
+type OrSpecification[T any] struct {
+	First, Second Specification[T]
+}
+
+func (s *OrSpecification[T]) IsSatisfied(item T) bool {
+	return s.First.IsSatisfied(item) || s.Second.IsSatisfied(item)
+}
+
+type NotSpecification[T any] struct {
+	Operand Specification[T]
+}
+
+func (s *NotSpecification[T]) IsSatisfied(item T) bool {
+	return !s.Operand.IsSatisfied(item)
+}
+
+type ComparisonSpecification[T any] struct {
+	Resolver FieldResolver[T]
+	Field    string
+	Op       tokenKind
+	Literal  any
+}
+
+func (s *ComparisonSpecification[T]) IsSatisfied(item T) bool {
+	value := s.Resolver.Resolve(item, s.Field)
+	return compareValues(value, s.Op, s.Literal)
+}
+
+func compareValues(value any, op tokenKind, literal any) bool {
+	switch left := value.(type) {
+	case string:
+		right, ok := literal.(string)
+		if !ok {
+			return false
+		}
+		cmp := strings.Compare(left, right)
+		switch op {
+		case tokEq:
+			return cmp == 0
+		case tokNeq:
+			return cmp != 0
+		case tokLt:
+			return cmp < 0
+		case tokLe:
+			return cmp <= 0
+		case tokGt:
+			return cmp > 0
+		case tokGe:
+			return cmp >= 0
+		}
+	case int:
+		right, ok := literal.(int)
+		if !ok {
+			return false
+		}
+		switch op {
+		case tokEq:
+			return left == right
+		case tokNeq:
+			return left != right
+		case tokLt:
+			return left < right
+		case tokLe:
+			return left <= right
+		case tokGt:
+			return left > right
+		case tokGe:
+			return left >= right
+		}
+	case float64:
+		right, ok := literal.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case tokEq:
+			return left == right
+		case tokNeq:
+			return left != right
+		case tokLt:
+			return left < right
+		case tokLe:
+			return left <= right
+		case tokGt:
+			return left > right
+		case tokGe:
+			return left >= right
+		}
+	}
+	return false
+}
+
+// ProductFieldResolver wires the query language up to Product so filters can
+// be expressed as strings such as `size == Large && color == Yellow`.
+type ProductFieldResolver struct{}
+
+func (ProductFieldResolver) Fields() map[string]reflect.Kind {
+	return map[string]reflect.Kind{
+		"name":  reflect.String,
+		"size":  reflect.Int,
+		"color": reflect.Int,
+	}
+}
+
+func (ProductFieldResolver) Resolve(p *Product, field string) any {
+	switch field {
+	case "name":
+		return p.name
+	case "size":
+		return int(p.size)
+	case "color":
+		return int(p.color)
+	default:
+		return nil
+	}
+}
+
+func (ProductFieldResolver) Constant(name string) (any, bool) {
+	switch name {
+	case "Small":
+		return int(Small), true
+	case "Medium":
+		return int(Medium), true
+	case "Large":
+		return int(Large), true
+	case "Giant":
+		return int(Giant), true
+	case "Red":
+		return int(Red), true
+	case "Green":
+		return int(Green), true
+	case "Blue":
+		return int(Blue), true
+	case "Yellow":
+		return int(Yellow), true
+	case "Black":
+		return int(Black), true
+	case "White":
+		return int(White), true
+	default:
+		return nil, false
+	}
+}
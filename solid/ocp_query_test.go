@@ -0,0 +1,103 @@
+package main
+
+import "testing"
+
+func compileProductQuery(t *testing.T, query string) Specification[*Product] {
+	t.Helper()
+	spec, err := Compile[*Product](query, ProductFieldResolver{})
+	if err != nil {
+		t.Fatalf("Compile(%q) returned unexpected error: %v", query, err)
+	}
+	return spec
+}
+
+func TestCompileHappyPath(t *testing.T) {
+	truck := NewProduct("Truck", Large, Red)
+	train := NewProduct("Train", Large, Yellow)
+	bike := NewProduct("Bike", Small, Blue)
+
+	spec := compileProductQuery(t, `size == Large && (color == Yellow || color == Red)`)
+	filter := &RightFilter[*Product]{}
+	got := filter.Filter([]*Product{truck, train, bike}, spec)
+
+	if len(got) != 2 || got[0] != truck || got[1] != train {
+		t.Fatalf("unexpected filter result: %+v", got)
+	}
+}
+
+func TestCompileOperators(t *testing.T) {
+	cases := []struct {
+		query string
+		name  string
+		want  bool
+	}{
+		{`name == "Bike"`, "Bike", true},
+		{`name != "Bike"`, "Bike", false},
+		{`size < Large`, "Bike", true},
+		{`size <= Small`, "Bike", true},
+		{`size > Small`, "Bike", false},
+		{`size >= Giant`, "Bike", false},
+		{`!(size == Large)`, "Bike", true},
+	}
+
+	bike := NewProduct("Bike", Small, Blue)
+	for _, tc := range cases {
+		spec := compileProductQuery(t, tc.query)
+		if got := spec.IsSatisfied(bike); got != tc.want {
+			t.Errorf("query %q: got %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestCompileUnknownField(t *testing.T) {
+	_, err := Compile[*Product](`weight == 10`, ProductFieldResolver{})
+	if err == nil {
+		t.Fatal("expected an error for an unknown field, got nil")
+	}
+
+	perr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("expected a *ParseError, got %T", err)
+	}
+	if perr.Pos != 0 {
+		t.Errorf("expected the error position to point at the field, got %d", perr.Pos)
+	}
+}
+
+func TestCompileUnknownIdentifierLiteral(t *testing.T) {
+	_, err := Compile[*Product](`color == Purple`, ProductFieldResolver{})
+	if err == nil {
+		t.Fatal("expected an error for an unknown constant, got nil")
+	}
+}
+
+func TestCompileTypeMismatch(t *testing.T) {
+	_, err := Compile[*Product](`name == 10`, ProductFieldResolver{})
+	if err == nil {
+		t.Fatal("expected a type mismatch error, got nil")
+	}
+}
+
+func TestCompileUnterminatedString(t *testing.T) {
+	_, err := Compile[*Product](`name == "Bike`, ProductFieldResolver{})
+	if err == nil {
+		t.Fatal("expected an error for an unterminated string literal, got nil")
+	}
+}
+
+func TestCompileMalformedExpressions(t *testing.T) {
+	queries := []string{
+		`size ==`,
+		`size == Large &&`,
+		`(size == Large`,
+		`size Large`,
+		`size === Large`,
+		``,
+	}
+
+	for _, query := range queries {
+		if _, err := Compile[*Product](query, ProductFieldResolver{}); err == nil {
+			t.Errorf("query %q: expected an error, got nil", query)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import "testing"
+
+func TestCombinators(t *testing.T) {
+	isEven := Predicate[int](func(n int) bool { return n%2 == 0 })
+	isPositive := Predicate[int](func(n int) bool { return n > 0 })
+
+	cases := []struct {
+		name string
+		spec Specification[int]
+		in   int
+		want bool
+	}{
+		{"and true", And[int](isEven, isPositive), 4, true},
+		{"and false", And[int](isEven, isPositive), -4, false},
+		{"or neither", Or[int](isEven, isPositive), -3, false},
+		{"or false->true", Or[int](isEven, isPositive), 3, true},
+		{"not", Not[int](isEven), 3, true},
+		{"xor same", Xor[int](isEven, isPositive), 4, false},
+		{"xor different", Xor[int](isEven, isPositive), -4, true},
+		{"all true", All[int](isEven, isPositive), 4, true},
+		{"all one false", All[int](isEven, isPositive), 3, false},
+		{"any true", Any[int](isEven, isPositive), 3, true},
+		{"any false", Any[int](isEven, isPositive), -3, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.spec.IsSatisfied(tc.in); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSpecBuilder(t *testing.T) {
+	truck := NewProduct("Truck", Large, Red)
+	train := NewProduct("Train", Large, Yellow)
+	bike := NewProduct("Bike", Small, Yellow)
+
+	spec := Spec[*Product]().
+		Where(sizeField.Eq(Large)).
+		And(colorField.In(Red, Yellow)).
+		Build()
+
+	filter := &RightFilter[*Product]{}
+	got := filter.Filter([]*Product{truck, train, bike}, spec)
+
+	if len(got) != 2 || got[0] != truck || got[1] != train {
+		t.Fatalf("unexpected filter result: %+v", got)
+	}
+}
+
+func TestFilterOverProducts(t *testing.T) {
+	bike := NewProduct("Bike", Small, Blue)
+	car := NewProduct("Car", Medium, Green)
+	truck := NewProduct("Truck", Large, Red)
+
+	filter := &RightFilter[*Product]{}
+	small := filter.Filter([]*Product{bike, car, truck}, sizeField.Eq(Small))
+
+	if len(small) != 1 || small[0] != bike {
+		t.Fatalf("expected only the bike, got %+v", small)
+	}
+}
+
+func TestFilterOverUsers(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+
+	users := []user{{"Ana", 17}, {"Bob", 34}, {"Cora", 65}}
+	age := OrderedFieldOf(func(u user) int { return u.age })
+
+	filter := &RightFilter[user]{}
+	adults := filter.Filter(users, age.Between(18, 64))
+
+	if len(adults) != 1 || adults[0].name != "Bob" {
+		t.Fatalf("expected only Bob, got %+v", adults)
+	}
+}
+
+func TestFilterOverOrders(t *testing.T) {
+	type order struct {
+		id     string
+		status string
+	}
+
+	orders := []order{{"A1", "pending"}, {"A2", "shipped"}, {"A3", "cancelled"}}
+	status := FieldOf(func(o order) string { return o.status })
+	open := All[order](Not(status.Eq("cancelled")), Not(status.Eq("shipped")))
+
+	filter := &RightFilter[order]{}
+	got := filter.Filter(orders, open)
+
+	if len(got) != 1 || got[0].id != "A1" {
+		t.Fatalf("expected only A1, got %+v", got)
+	}
+}
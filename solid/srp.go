@@ -1,8 +1,17 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/syslog"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 /*
@@ -16,16 +25,16 @@ import (
  	Ref: https://stackoverflow.com/questions/10620022/example-of-single-responsibility-principle
 */
 
-type Logger struct {
+// !!! This is wrong. This violates SRP
+type LegacyLogger struct {
 	logEntries []string
 }
 
-func (l *Logger) Log(entry string) {
+func (l *LegacyLogger) Log(entry string) {
 	l.logEntries = append(l.logEntries, entry)
 }
 
-// !!! This is wrong. This violates SRP
-func (l *Logger) Save(filename string) error {
+func (l *LegacyLogger) Save(filename string) error {
 	f, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -42,7 +51,7 @@ func (l *Logger) Save(filename string) error {
 type LogFileWriter struct {
 }
 
-func (lfw *LogFileWriter) Save(logger *Logger, filename string) error {
+func (lfw *LogFileWriter) Save(logger *LegacyLogger, filename string) error {
 	f, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -55,17 +64,471 @@ func (lfw *LogFileWriter) Save(logger *Logger, filename string) error {
 	return nil
 }
 
+// Level is the severity of a LogEntry.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (lv Level) String() string {
+	switch lv {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// LogEntry is the structured unit of work that flows from Logger to every LogSink.
+type LogEntry struct {
+	Timestamp time.Time
+	Level     Level
+	Fields    map[string]interface{}
+	Message   string
+}
+
+// LogSink is the abstraction Logger depends on. Concrete sinks (file, network,
+// in-memory, ...) implement it; Logger never knows which one it is talking to,
+// which is what keeps this DIP-clean on top of being SRP-clean.
+type LogSink interface {
+	Write(entry LogEntry) error
+	Flush() error
+	Close() error
+}
+
+// sinkRegistration pairs a sink with the minimum level it accepts.
+type sinkRegistration struct {
+	sink     LogSink
+	minLevel Level
+}
+
+// Logger owns nothing but the responsibility of routing structured entries to
+// whichever sinks are registered. Delivery is asynchronous: entries are handed
+// to a channel and a single worker goroutine fans them out to every sink,
+// batching writes so slow sinks don't stall callers of Log.
+type Logger struct {
+	mu            sync.Mutex
+	sinks         []sinkRegistration
+	entries       chan LogEntry
+	batchSize     int
+	flushInterval time.Duration
+	done          chan struct{}
+	wg            sync.WaitGroup
+	shutdownOnce  sync.Once
+}
+
+// NewLogger creates a Logger with an async worker ready to receive entries.
+// queueSize bounds how many entries may be buffered before Log blocks.
+func NewLogger(queueSize, batchSize int, flushInterval time.Duration) *Logger {
+	l := &Logger{
+		entries:       make(chan LogEntry, queueSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+
+	l.wg.Add(1)
+	go l.run()
+
+	return l
+}
+
+// RegisterSink attaches a sink that only receives entries at or above minLevel.
+func (l *Logger) RegisterSink(sink LogSink, minLevel Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, sinkRegistration{sink: sink, minLevel: minLevel})
+}
+
+// ErrLoggerClosed is returned by Log once Shutdown has been called; nothing
+// is left to drain the entries channel, so Log must not block forever.
+var ErrLoggerClosed = errors.New("srp: logger is closed")
+
+// Log enqueues a structured entry for asynchronous delivery to every sink.
+// It returns ErrLoggerClosed instead of blocking if the Logger has already
+// been shut down.
+func (l *Logger) Log(level Level, message string, fields map[string]interface{}) error {
+	entry := LogEntry{
+		Timestamp: time.Now(),
+		Level:     level,
+		Fields:    fields,
+		Message:   message,
+	}
+
+	select {
+	case <-l.done:
+		return ErrLoggerClosed
+	default:
+	}
+
+	select {
+	case l.entries <- entry:
+		return nil
+	case <-l.done:
+		return ErrLoggerClosed
+	}
+}
+
+// run is the worker goroutine. It batches incoming entries and flushes either
+// when a batch fills up or when flushInterval elapses, whichever is first.
+func (l *Logger) run() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]LogEntry, 0, l.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		l.deliver(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case entry := <-l.entries:
+			batch = append(batch, entry)
+			if len(batch) >= l.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-l.done:
+			for {
+				select {
+				case entry := <-l.entries:
+					batch = append(batch, entry)
+				default:
+					flush()
+					l.closeSinks()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (l *Logger) deliver(batch []LogEntry) {
+	l.mu.Lock()
+	sinks := append([]sinkRegistration(nil), l.sinks...)
+	l.mu.Unlock()
+
+	for _, reg := range sinks {
+		for _, entry := range batch {
+			if entry.Level < reg.minLevel {
+				continue
+			}
+			// Best-effort: a misbehaving sink must not take down the others.
+			if err := reg.sink.Write(entry); err != nil {
+				fmt.Fprintf(os.Stderr, "logsink write error: %v\n", err)
+			}
+		}
+		reg.sink.Flush()
+	}
+}
+
+func (l *Logger) closeSinks() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, reg := range l.sinks {
+		reg.sink.Close()
+	}
+}
+
+// Shutdown drains any pending entries, flushes every sink and closes them.
+// It blocks until the worker goroutine has finished. Shutdown may be called
+// more than once; only the first call has any effect.
+func (l *Logger) Shutdown() {
+	l.shutdownOnce.Do(func() {
+		close(l.done)
+		l.wg.Wait()
+	})
+}
+
+// FileSink writes each entry as a plain line to a single file.
+type FileSink struct {
+	file *os.File
+}
+
+func NewFileSink(filename string) (*FileSink, error) {
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: f}, nil
+}
+
+func (fs *FileSink) Write(entry LogEntry) error {
+	_, err := fs.file.WriteString(formatEntry(entry) + "\n")
+	return err
+}
+
+func (fs *FileSink) Flush() error {
+	return fs.file.Sync()
+}
+
+func (fs *FileSink) Close() error {
+	return fs.file.Close()
+}
+
+// RotatingFileSink rolls over to a new file once the current one exceeds
+// maxBytes, or once it has been open for longer than maxAge.
+type RotatingFileSink struct {
+	dir      string
+	prefix   string
+	maxBytes int64
+	maxAge   time.Duration
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+func NewRotatingFileSink(dir, prefix string, maxBytes int64, maxAge time.Duration) (*RotatingFileSink, error) {
+	rfs := &RotatingFileSink{
+		dir:      dir,
+		prefix:   prefix,
+		maxBytes: maxBytes,
+		maxAge:   maxAge,
+	}
+	if err := rfs.rotate(); err != nil {
+		return nil, err
+	}
+	return rfs, nil
+}
+
+func (rfs *RotatingFileSink) rotate() error {
+	if rfs.file != nil {
+		rfs.file.Close()
+	}
+
+	name := filepath.Join(rfs.dir, fmt.Sprintf("%s.%d.log", rfs.prefix, time.Now().UnixNano()))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	rfs.file = f
+	rfs.size = 0
+	rfs.openedAt = time.Now()
+	return nil
+}
+
+func (rfs *RotatingFileSink) Write(entry LogEntry) error {
+	rfs.mu.Lock()
+	defer rfs.mu.Unlock()
+
+	if rfs.size >= rfs.maxBytes || time.Since(rfs.openedAt) >= rfs.maxAge {
+		if err := rfs.rotate(); err != nil {
+			return err
+		}
+	}
+
+	line := formatEntry(entry) + "\n"
+	n, err := rfs.file.WriteString(line)
+	rfs.size += int64(n)
+	return err
+}
+
+func (rfs *RotatingFileSink) Flush() error {
+	rfs.mu.Lock()
+	defer rfs.mu.Unlock()
+	return rfs.file.Sync()
+}
+
+func (rfs *RotatingFileSink) Close() error {
+	rfs.mu.Lock()
+	defer rfs.mu.Unlock()
+	return rfs.file.Close()
+}
+
+// SyslogSink forwards entries to the local syslog daemon.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+func NewSyslogSink(tag string) (*SyslogSink, error) {
+	w, err := syslog.New(syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{writer: w}, nil
+}
+
+func (ss *SyslogSink) Write(entry LogEntry) error {
+	line := formatEntry(entry)
+	switch entry.Level {
+	case LevelDebug:
+		return ss.writer.Debug(line)
+	case LevelWarn:
+		return ss.writer.Warning(line)
+	case LevelError:
+		return ss.writer.Err(line)
+	default:
+		return ss.writer.Info(line)
+	}
+}
+
+func (ss *SyslogSink) Flush() error {
+	return nil
+}
+
+func (ss *SyslogSink) Close() error {
+	return ss.writer.Close()
+}
+
+// HTTPSink pushes each entry as a JSON document to a remote collector.
+type HTTPSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewHTTPSink(endpoint string) *HTTPSink {
+	return &HTTPSink{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (hs *HTTPSink) Write(entry LogEntry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hs.client.Post(hs.endpoint, "application/json", strings.NewReader(string(payload)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("http sink: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (hs *HTTPSink) Flush() error {
+	return nil
+}
+
+func (hs *HTTPSink) Close() error {
+	return nil
+}
+
+// MemorySink keeps the last capacity entries in memory. It is meant for tests
+// that want to assert on what was logged without touching the filesystem.
+type MemorySink struct {
+	mu       sync.Mutex
+	capacity int
+	entries  []LogEntry
+}
+
+func NewMemorySink(capacity int) *MemorySink {
+	return &MemorySink{capacity: capacity}
+}
+
+func (ms *MemorySink) Write(entry LogEntry) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.entries = append(ms.entries, entry)
+	if len(ms.entries) > ms.capacity {
+		ms.entries = ms.entries[len(ms.entries)-ms.capacity:]
+	}
+	return nil
+}
+
+func (ms *MemorySink) Flush() error {
+	return nil
+}
+
+func (ms *MemorySink) Close() error {
+	return nil
+}
+
+// Entries returns a snapshot of everything currently held in the ring buffer.
+func (ms *MemorySink) Entries() []LogEntry {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return append([]LogEntry(nil), ms.entries...)
+}
+
+func formatEntry(entry LogEntry) string {
+	var b strings.Builder
+	b.WriteString(entry.Timestamp.Format(time.RFC3339))
+	b.WriteByte(' ')
+	b.WriteString(entry.Level.String())
+	b.WriteByte(' ')
+	b.WriteString(entry.Message)
+
+	for k, v := range entry.Fields {
+		b.WriteByte(' ')
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(fieldValue(v))
+	}
+
+	return b.String()
+}
+
+func fieldValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case int:
+		return strconv.Itoa(val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
 func main() {
 	fmt.Println("Single Responsibility Principle")
 
-	logger := &Logger{}
-	logger.Log("entry1")
-	logger.Log("entry2")
+	legacyLogger := &LegacyLogger{}
+	legacyLogger.Log("entry1")
+	legacyLogger.Log("entry2")
 
 	// !!! This is wrong. This violates SRP
-	logger.Save("./wrong.log")
+	legacyLogger.Save("./wrong.log")
 
-	// !!! This is better
+	// !!! This is better, but still only ever writes to a single file.
 	logWriter := &LogFileWriter{}
-	logWriter.Save(logger, "./better.log")
+	logWriter.Save(legacyLogger, "./better.log")
+
+	// !!! This is the DIP-friendly version: Logger depends only on LogSink.
+	logger := NewLogger(100, 10, time.Second)
+
+	memSink := NewMemorySink(50)
+	logger.RegisterSink(memSink, LevelDebug)
+
+	if fileSink, err := NewFileSink("./structured.log"); err == nil {
+		logger.RegisterSink(fileSink, LevelInfo)
+	}
+
+	if err := logger.Log(LevelInfo, "service started", map[string]interface{}{"port": 8080}); err != nil {
+		fmt.Println("log failed:", err)
+	}
+	if err := logger.Log(LevelError, "failed to connect to upstream", map[string]interface{}{"retry": 3}); err != nil {
+		fmt.Println("log failed:", err)
+	}
+
+	logger.Shutdown()
+
+	fmt.Println("entries captured in memory sink:", len(memSink.Entries()))
 }
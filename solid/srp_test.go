@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoggerShutdownIsIdempotent(t *testing.T) {
+	l := NewLogger(10, 5, time.Hour)
+	l.Shutdown()
+	l.Shutdown() // must not panic
+}
+
+func TestLoggerLogAfterShutdownReturnsError(t *testing.T) {
+	l := NewLogger(10, 5, time.Hour)
+	l.Shutdown()
+
+	err := l.Log(LevelInfo, "too late", nil)
+	if !errors.Is(err, ErrLoggerClosed) {
+		t.Fatalf("expected ErrLoggerClosed, got %v", err)
+	}
+}
+
+func TestLoggerLogAfterShutdownNeverBlocks(t *testing.T) {
+	// queueSize 1 means a second Log call after Shutdown would deadlock
+	// forever if Log didn't guard against a closed Logger.
+	l := NewLogger(1, 1, time.Hour)
+	l.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			l.Log(LevelInfo, "spin", nil)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Log blocked forever after Shutdown")
+	}
+}
+
+func TestPerSinkLevelFiltering(t *testing.T) {
+	l := NewLogger(10, 1, 10*time.Millisecond)
+
+	debugAndUp := NewMemorySink(10)
+	errorsOnly := NewMemorySink(10)
+
+	l.RegisterSink(debugAndUp, LevelDebug)
+	l.RegisterSink(errorsOnly, LevelError)
+
+	if err := l.Log(LevelDebug, "debug message", nil); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+	if err := l.Log(LevelError, "error message", nil); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+
+	l.Shutdown()
+
+	if got := len(debugAndUp.Entries()); got != 2 {
+		t.Fatalf("expected the debug+ sink to see both entries, got %d", got)
+	}
+	if got := len(errorsOnly.Entries()); got != 1 {
+		t.Fatalf("expected the errors-only sink to see a single entry, got %d", got)
+	}
+	if got := errorsOnly.Entries()[0].Message; got != "error message" {
+		t.Fatalf("errors-only sink got the wrong entry: %q", got)
+	}
+}
+
+func TestMemorySinkRingBufferEviction(t *testing.T) {
+	sink := NewMemorySink(3)
+
+	for i := 0; i < 5; i++ {
+		sink.Write(LogEntry{Message: string(rune('a' + i))})
+	}
+
+	entries := sink.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected the ring buffer to cap at 3 entries, got %d", len(entries))
+	}
+
+	want := []string{"c", "d", "e"}
+	for i, entry := range entries {
+		if entry.Message != want[i] {
+			t.Fatalf("entry %d = %q, want %q (oldest entries should have been evicted)", i, entry.Message, want[i])
+		}
+	}
+}